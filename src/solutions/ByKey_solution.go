@@ -0,0 +1,68 @@
+package solutions
+
+import "time"
+
+// KeyedMessage pairs a value with the key it should be conflated under, e.g.
+// a ticker symbol for a market-data feed where each symbol must be conflated
+// independently.
+type KeyedMessage[K comparable, T Conflater[T]] struct {
+	Key     K
+	Message T
+}
+
+// ConflateByKey is the keyed counterpart to ConflateV3: instead of a single
+// conflated value, the goroutine maintains one independently-conflated value
+// per key, so a slow downstream receiver never lets a busy key starve a
+// quiet one. Pending keys are emitted in fair round-robin order based on when
+// they first accumulated pending state.
+func ConflateByKey[K comparable, T Conflater[T]](retryInterval time.Duration) (chan<- KeyedMessage[K, T], <-chan KeyedMessage[K, T]) {
+	outCh := make(chan KeyedMessage[K, T])
+	inCh := make(chan KeyedMessage[K, T])
+	go func() {
+		conflated := make(map[K]T)
+		var pending []K
+		isPending := make(map[K]bool)
+		var retryTimer *time.Timer
+		var retryCh <-chan time.Time
+
+		for {
+			select {
+			case msg := <-inCh:
+				conflated[msg.Key] = conflated[msg.Key].ConflateWith(msg.Message)
+				if !isPending[msg.Key] {
+					isPending[msg.Key] = true
+					pending = append(pending, msg.Key)
+				}
+				if retryTimer != nil {
+					retryTimer.Stop()
+					retryCh = nil
+				}
+			case <-retryCh:
+			}
+
+			if len(pending) == 0 {
+				continue
+			}
+
+			key := pending[0]
+			select {
+			case outCh <- KeyedMessage[K, T]{Key: key, Message: conflated[key]}:
+				conflated[key] = T.ZeroValue(conflated[key])
+				pending = pending[1:]
+				delete(isPending, key)
+				if len(pending) > 0 {
+					// More keys are still waiting; retry immediately instead
+					// of blocking on the top select until the next inbound
+					// message, which would otherwise starve them indefinitely
+					// on a quiet stream.
+					retryTimer = time.NewTimer(0)
+					retryCh = retryTimer.C
+				}
+			default:
+				retryTimer = time.NewTimer(retryInterval)
+				retryCh = retryTimer.C
+			}
+		}
+	}()
+	return inCh, outCh
+}
@@ -0,0 +1,59 @@
+package solutions
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConflateByKeyConservation(t *testing.T) {
+	inCh, outCh := ConflateByKey[string, intAccumulator](time.Millisecond)
+
+	keys := []string{"BTC", "ETH", "SOL"}
+	const perKey = 200
+
+	want := make(map[string]int)
+	var wantMu sync.Mutex
+	var wg sync.WaitGroup
+	for _, k := range keys {
+		wg.Add(1)
+		go func(k string) {
+			defer wg.Done()
+			for i := 0; i < perKey; i++ {
+				inCh <- KeyedMessage[string, intAccumulator]{Key: k, Message: intAccumulator{sum: 1}}
+			}
+			wantMu.Lock()
+			want[k] += perKey
+			wantMu.Unlock()
+		}(k)
+	}
+
+	got := make(map[string]int)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		idle := time.NewTimer(200 * time.Millisecond)
+		defer idle.Stop()
+		for {
+			select {
+			case msg := <-outCh:
+				got[msg.Key] += msg.Message.sum
+				if !idle.Stop() {
+					<-idle.C
+				}
+				idle.Reset(200 * time.Millisecond)
+			case <-idle.C:
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	<-done
+
+	for _, k := range keys {
+		if got[k] != want[k] {
+			t.Errorf("key %s: got %d, want %d", k, got[k], want[k])
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package solutions
+
+import "time"
+
+// Conflator wraps the ConflateV3 goroutine behind a struct so late joiners
+// can ask for the currently accumulated value instead of only waiting on the
+// next emit. Send/Recv behave like the channel pair returned by ConflateV3;
+// Snapshot and Subscribe are built on top of internal command channels so
+// the goroutine remains the sole owner of conflatedMessage.
+type Conflator[T Conflater[T]] struct {
+	inCh          chan T
+	outCh         chan T
+	snapshotCh    chan chan T
+	subscribeCh   chan chan T
+	unsubscribeCh chan chan T
+}
+
+// conflatorSubscriber tracks a subscriber's own pending conflated value,
+// independent of the primary conflatedMessage and of every other subscriber,
+// so a subscriber that's slow to drain its channel conflates the deltas it
+// misses instead of losing them.
+type conflatorSubscriber[T Conflater[T]] struct {
+	ch         chan T
+	pending    T
+	hasPending bool
+}
+
+// NewConflator starts the conflating goroutine and returns a Conflator handle
+// to it.
+func NewConflator[T Conflater[T]](retryInterval time.Duration) *Conflator[T] {
+	c := &Conflator[T]{
+		inCh:          make(chan T),
+		outCh:         make(chan T),
+		snapshotCh:    make(chan chan T),
+		subscribeCh:   make(chan chan T),
+		unsubscribeCh: make(chan chan T),
+	}
+	go c.run(retryInterval)
+	return c
+}
+
+// Send enqueues a message to be conflated, same as sending on ConflateV3's
+// inCh.
+func (c *Conflator[T]) Send(msg T) {
+	c.inCh <- msg
+}
+
+// Recv returns the channel of conflated values, same as ConflateV3's outCh.
+func (c *Conflator[T]) Recv() <-chan T {
+	return c.outCh
+}
+
+// Snapshot returns the currently accumulated, not-yet-emitted value without
+// consuming it.
+func (c *Conflator[T]) Snapshot() T {
+	reply := make(chan T)
+	c.snapshotCh <- reply
+	return <-reply
+}
+
+// Subscribe registers an additional output channel that first receives the
+// current snapshot and then live conflated updates, matching how market-data
+// consumers typically want an initial state followed by deltas. Each
+// subscriber conflates independently, so a slow subscriber never loses a
+// delta to a faster one. The returned func unregisters the subscription.
+func (c *Conflator[T]) Subscribe() (<-chan T, func()) {
+	sub := make(chan T, 1)
+	c.subscribeCh <- sub
+	return sub, func() {
+		c.unsubscribeCh <- sub
+	}
+}
+
+func (c *Conflator[T]) run(retryInterval time.Duration) {
+	var conflatedMessage T
+	var retryTimer *time.Timer
+	var retryCh <-chan time.Time
+	var subscribers []*conflatorSubscriber[T]
+
+	// flushSubscribers attempts, independently of the primary outCh, to
+	// deliver each subscriber's pending value. It runs on every loop tick
+	// regardless of whether outCh had a reader, so Subscribe-only consumers
+	// keep receiving updates even if nobody ever drains Recv().
+	flushSubscribers := func() {
+		for _, sub := range subscribers {
+			if !sub.hasPending {
+				continue
+			}
+			select {
+			case sub.ch <- sub.pending:
+				sub.pending = T.ZeroValue(sub.pending)
+				sub.hasPending = false
+			default:
+			}
+		}
+	}
+
+	for {
+		select {
+		case lastMsg := <-c.inCh:
+			conflatedMessage = conflatedMessage.ConflateWith(lastMsg)
+			for _, sub := range subscribers {
+				sub.pending = sub.pending.ConflateWith(lastMsg)
+				sub.hasPending = true
+			}
+			if retryTimer != nil {
+				retryTimer.Stop()
+				retryCh = nil
+			}
+		case <-retryCh:
+		case reply := <-c.snapshotCh:
+			reply <- conflatedMessage
+			continue
+		case ch := <-c.subscribeCh:
+			ch <- conflatedMessage
+			subscribers = append(subscribers, &conflatorSubscriber[T]{ch: ch})
+			continue
+		case ch := <-c.unsubscribeCh:
+			for i, existing := range subscribers {
+				if existing.ch == ch {
+					subscribers = append(subscribers[:i], subscribers[i+1:]...)
+					break
+				}
+			}
+			continue
+		}
+
+		flushSubscribers()
+
+		needRetry := false
+		select {
+		case c.outCh <- conflatedMessage:
+			conflatedMessage = T.ZeroValue(conflatedMessage)
+		default:
+			needRetry = true
+		}
+		for _, sub := range subscribers {
+			if sub.hasPending {
+				needRetry = true
+				break
+			}
+		}
+
+		if needRetry {
+			retryTimer = time.NewTimer(retryInterval)
+			retryCh = retryTimer.C
+		}
+	}
+}
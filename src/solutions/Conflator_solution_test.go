@@ -0,0 +1,92 @@
+package solutions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConflatorSnapshotAndSubscribe(t *testing.T) {
+	c := NewConflator[intAccumulator](time.Millisecond)
+
+	c.Send(intAccumulator{sum: 2})
+	c.Send(intAccumulator{sum: 3})
+
+	if snap := c.Snapshot(); snap.sum != 5 {
+		t.Fatalf("snapshot = %d, want 5", snap.sum)
+	}
+
+	sub1, cancel1 := c.Subscribe()
+	defer cancel1()
+	if initial := <-sub1; initial.sum != 5 {
+		t.Fatalf("subscriber 1 initial snapshot = %d, want 5", initial.sum)
+	}
+
+	sub2, cancel2 := c.Subscribe()
+	defer cancel2()
+	if initial := <-sub2; initial.sum != 5 {
+		t.Fatalf("subscriber 2 initial snapshot = %d, want 5", initial.sum)
+	}
+
+	c.Send(intAccumulator{sum: 4})
+
+	if got := <-sub1; got.sum != 4 {
+		t.Errorf("subscriber 1 update = %d, want 4", got.sum)
+	}
+	if got := <-sub2; got.sum != 4 {
+		t.Errorf("subscriber 2 update = %d, want 4", got.sum)
+	}
+}
+
+// TestConflatorSubscribeWithoutRecvStillDelivers guards against a subscriber
+// being starved when nobody ever drains Recv(): the feature exists for
+// Subscribe-only consumers, so a delta must reach them even if the primary
+// channel has no reader.
+func TestConflatorSubscribeWithoutRecvStillDelivers(t *testing.T) {
+	c := NewConflator[intAccumulator](time.Millisecond)
+
+	sub, cancel := c.Subscribe()
+	defer cancel()
+	<-sub // initial snapshot
+
+	c.Send(intAccumulator{sum: 1})
+
+	select {
+	case got := <-sub:
+		if got.sum != 1 {
+			t.Errorf("got %d, want 1", got.sum)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscriber never received update although Recv() was never drained")
+	}
+}
+
+// TestConflatorSubscriberConflatesWhenSlow asserts a subscriber that isn't
+// draining immediately still receives the full conflated sum of everything
+// sent in the meantime, rather than losing deltas that arrived while its
+// buffer was full.
+func TestConflatorSubscriberConflatesWhenSlow(t *testing.T) {
+	c := NewConflator[intAccumulator](time.Millisecond)
+
+	sub, cancel := c.Subscribe()
+	defer cancel()
+	<-sub // initial snapshot
+
+	c.Send(intAccumulator{sum: 1})
+	c.Send(intAccumulator{sum: 2})
+	c.Send(intAccumulator{sum: 3})
+
+	time.Sleep(20 * time.Millisecond)
+
+	total := 0
+	for {
+		select {
+		case v := <-sub:
+			total += v.sum
+		case <-time.After(50 * time.Millisecond):
+			if total != 6 {
+				t.Errorf("subscriber received total %d, want 6", total)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package solutions
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestConflateV1WithContextShutdown(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	inCh, outCh := ConflateV1WithContext[int](ctx)
+
+	var received []int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for v := range outCh {
+			received = append(received, v)
+		}
+	}()
+
+	inCh <- 1
+	inCh <- 2
+	inCh <- 3
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	<-done
+
+	if len(received) == 0 || received[len(received)-1] != 3 {
+		t.Errorf("drained values = %v, want last value 3", received)
+	}
+
+	waitForGoroutineCount(t, before)
+}
+
+func TestConflateV2WithContextShutdown(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	inCh, outCh := ConflateV2WithContext[int](ctx, time.Millisecond)
+
+	var received []int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for v := range outCh {
+			received = append(received, v)
+		}
+	}()
+
+	inCh <- 1
+	inCh <- 2
+	inCh <- 3
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	<-done
+
+	if len(received) == 0 || received[len(received)-1] != 3 {
+		t.Errorf("drained values = %v, want last value 3", received)
+	}
+
+	waitForGoroutineCount(t, before)
+}
+
+func TestConflateV3WithContextShutdown(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	inCh, outCh := ConflateV3WithContext[intAccumulator](ctx, time.Millisecond)
+
+	total := 0
+	received := 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for v := range outCh {
+			received += v.sum
+		}
+	}()
+
+	for i := 1; i <= 3; i++ {
+		inCh <- intAccumulator{sum: i}
+		total += i
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	<-done
+
+	if received != total {
+		t.Errorf("total drained sum = %d, want %d (conservation across the final flush)", received, total)
+	}
+
+	waitForGoroutineCount(t, before)
+}
+
+func TestConflateV1WithContextNoDuplicateFinalEmit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	inCh, outCh := ConflateV1WithContext[int](ctx)
+
+	inCh <- 1
+	if v := <-outCh; v != 1 {
+		t.Fatalf("got %d, want 1", v)
+	}
+
+	cancel()
+
+	var received []int
+	for v := range outCh {
+		received = append(received, v)
+	}
+	if len(received) != 0 {
+		t.Errorf("expected no further emits once the value was already delivered, got %v", received)
+	}
+}
+
+func TestConflateV2WithContextNoDuplicateFinalEmit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	inCh, outCh := ConflateV2WithContext[int](ctx, time.Millisecond)
+
+	inCh <- 1
+	if v := <-outCh; v != 1 {
+		t.Fatalf("got %d, want 1", v)
+	}
+
+	cancel()
+
+	var received []int
+	for v := range outCh {
+		received = append(received, v)
+	}
+	if len(received) != 0 {
+		t.Errorf("expected no further emits once the value was already delivered, got %v", received)
+	}
+}
+
+func TestConflateV3WithContextNoDuplicateFinalEmit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	inCh, outCh := ConflateV3WithContext[intAccumulator](ctx, time.Millisecond)
+
+	inCh <- intAccumulator{sum: 1}
+	if v := <-outCh; v.sum != 1 {
+		t.Fatalf("got %d, want 1", v.sum)
+	}
+
+	cancel()
+
+	var received []intAccumulator
+	for v := range outCh {
+		received = append(received, v)
+	}
+	if len(received) != 0 {
+		t.Errorf("expected no further emits once the value was already delivered, got %v", received)
+	}
+}
+
+func TestConflateV1WithContextPostCancelSendIsDropped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	inCh, outCh := ConflateV1WithContext[int](ctx)
+	go func() {
+		for range outCh {
+		}
+	}()
+
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		inCh <- 99
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("send on inCh after cancel blocked instead of being dropped")
+	}
+}
+
+func TestConflateV3WithContextPostCancelSendIsDropped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	inCh, outCh := ConflateV3WithContext[intAccumulator](ctx, time.Millisecond)
+	go func() {
+		for range outCh {
+		}
+	}()
+
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		inCh <- intAccumulator{sum: 99}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("send on inCh after cancel blocked instead of being dropped")
+	}
+}
+
+func waitForGoroutineCount(t *testing.T, before int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Errorf("goroutine leak: have %d, started with %d", runtime.NumGoroutine(), before)
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -1,5 +1,7 @@
 package solutions
 
+import "context"
+
 func ConflateV1[T any]() (chan<- T, <-chan T) {
 	outCh := make(chan T)
 	inCh := make(chan T)
@@ -15,3 +17,49 @@ func ConflateV1[T any]() (chan<- T, <-chan T) {
 	}()
 	return inCh, outCh
 }
+
+// ConflateV1WithContext is ConflateV1 with graceful shutdown: the goroutine
+// exits once ctx is done, first draining anything already pending on inCh
+// (including a send racing with cancellation) and making one final
+// best-effort send of the last received message, if any. inCh is buffered by
+// one and never closed, so a send after shutdown does not panic: the first
+// such send fills the buffer and is dropped, but any further send blocks.
+func ConflateV1WithContext[T any](ctx context.Context) (chan<- T, <-chan T) {
+	outCh := make(chan T)
+	inCh := make(chan T, 1)
+	go func() {
+		defer close(outCh)
+		var lastMsg T
+		var hasMsg bool
+		for {
+			select {
+			case lastMsg = <-inCh:
+				hasMsg = true
+			case <-ctx.Done():
+				for drained := false; !drained; {
+					select {
+					case msg := <-inCh:
+						lastMsg = msg
+						hasMsg = true
+					default:
+						drained = true
+					}
+				}
+				if hasMsg {
+					select {
+					case outCh <- lastMsg:
+					default:
+					}
+				}
+				return
+			}
+
+			select {
+			case outCh <- lastMsg:
+				hasMsg = false
+			default:
+			}
+		}
+	}()
+	return inCh, outCh
+}
@@ -1,6 +1,7 @@
 package solutions
 
 import (
+	"context"
 	"time"
 )
 
@@ -31,3 +32,58 @@ func ConflateV2[T any](retryInterval time.Duration) (chan<- T, <-chan T) {
 	}()
 	return inCh, outCh
 }
+
+// ConflateV2WithContext is ConflateV2 with graceful shutdown: the goroutine
+// exits once ctx is done, first draining anything already pending on inCh
+// (including a send racing with cancellation) and making one final
+// best-effort send of the last received message, if any. inCh is buffered by
+// one and never closed, so a send after shutdown does not panic: the first
+// such send fills the buffer and is dropped, but any further send blocks.
+func ConflateV2WithContext[T any](ctx context.Context, retryInterval time.Duration) (chan<- T, <-chan T) {
+	outCh := make(chan T)
+	inCh := make(chan T, 1)
+	go func() {
+		defer close(outCh)
+		var lastMsg T
+		var hasMsg bool
+		var retryTimer *time.Timer
+		var retryCh <-chan time.Time
+		for {
+			select {
+			case lastMsg = <-inCh:
+				hasMsg = true
+				if retryTimer != nil {
+					retryTimer.Stop()
+					retryCh = nil
+				}
+			case <-retryCh:
+			case <-ctx.Done():
+				for drained := false; !drained; {
+					select {
+					case msg := <-inCh:
+						lastMsg = msg
+						hasMsg = true
+					default:
+						drained = true
+					}
+				}
+				if hasMsg {
+					select {
+					case outCh <- lastMsg:
+					default:
+					}
+				}
+				return
+			}
+
+			select {
+			case outCh <- lastMsg:
+				hasMsg = false
+			default:
+				retryTimer = time.NewTimer(retryInterval)
+				retryCh = retryTimer.C
+			}
+		}
+	}()
+	return inCh, outCh
+}
@@ -1,6 +1,9 @@
 package solutions
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type Conflater[C any] interface {
 	ConflateWith(latest C) C
@@ -36,3 +39,61 @@ func ConflateV3[T Conflater[T]](retryInterval time.Duration) (chan<- T, <-chan T
 	}()
 	return inCh, outCh
 }
+
+// ConflateV3WithContext is ConflateV3 with graceful shutdown: the goroutine
+// exits once ctx is done, first draining anything already pending on inCh
+// (including a send racing with cancellation) and making one final
+// best-effort send of the pending conflated value, if any, then closes
+// outCh. inCh is buffered by one and never closed, so a send after shutdown
+// does not panic: the first such send fills the buffer and is dropped, but any
+// further send blocks.
+func ConflateV3WithContext[T Conflater[T]](ctx context.Context, retryInterval time.Duration) (chan<- T, <-chan T) {
+	outCh := make(chan T)
+	inCh := make(chan T, 1)
+	go func() {
+		defer close(outCh)
+		var conflatedMessage T
+		var hasMsg bool
+		var retryTimer *time.Timer
+		var retryCh <-chan time.Time
+		for {
+			select {
+			case lastMsg := <-inCh:
+				conflatedMessage = conflatedMessage.ConflateWith(lastMsg)
+				hasMsg = true
+				if retryTimer != nil {
+					retryTimer.Stop()
+					retryCh = nil
+				}
+			case <-retryCh:
+			case <-ctx.Done():
+				for drained := false; !drained; {
+					select {
+					case msg := <-inCh:
+						conflatedMessage = conflatedMessage.ConflateWith(msg)
+						hasMsg = true
+					default:
+						drained = true
+					}
+				}
+				if hasMsg {
+					select {
+					case outCh <- conflatedMessage:
+					default:
+					}
+				}
+				return
+			}
+
+			select {
+			case outCh <- conflatedMessage:
+				conflatedMessage = T.ZeroValue(conflatedMessage)
+				hasMsg = false
+			default:
+				retryTimer = time.NewTimer(retryInterval)
+				retryCh = retryTimer.C
+			}
+		}
+	}()
+	return inCh, outCh
+}
@@ -0,0 +1,45 @@
+package solutions
+
+import "time"
+
+// ConflateV4 extends ConflateV3 with a minEmitInterval: the output channel
+// never emits more often than that, conflating everything that arrives in
+// between emits. This is independent of retryInterval, which only governs
+// how quickly a send is retried once the receiver isn't ready.
+func ConflateV4[T Conflater[T]](retryInterval, minEmitInterval time.Duration) (chan<- T, <-chan T) {
+	outCh := make(chan T)
+	inCh := make(chan T)
+	go func() {
+		var conflatedMessage T
+		var retryTimer *time.Timer
+		var retryCh <-chan time.Time
+		nextEmit := time.Now()
+		for {
+			select {
+			case lastMsg := <-inCh:
+				conflatedMessage = conflatedMessage.ConflateWith(lastMsg)
+				if retryTimer != nil {
+					retryTimer.Stop()
+					retryCh = nil
+				}
+			case <-retryCh:
+			}
+
+			if delay := nextEmit.Sub(time.Now()); delay > 0 {
+				retryTimer = time.NewTimer(delay)
+				retryCh = retryTimer.C
+				continue
+			}
+
+			select {
+			case outCh <- conflatedMessage:
+				conflatedMessage = T.ZeroValue(conflatedMessage)
+				nextEmit = time.Now().Add(minEmitInterval)
+			default:
+				retryTimer = time.NewTimer(retryInterval)
+				retryCh = retryTimer.C
+			}
+		}
+	}()
+	return inCh, outCh
+}
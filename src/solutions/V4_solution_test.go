@@ -0,0 +1,46 @@
+package solutions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConflateV4ThrottlesEmitRate(t *testing.T) {
+	const minEmitInterval = 50 * time.Millisecond
+	inCh, outCh := ConflateV4[intAccumulator](time.Millisecond, minEmitInterval)
+
+	stop := time.After(300 * time.Millisecond)
+	go func() {
+		for {
+			select {
+			case inCh <- intAccumulator{sum: 1}:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var timestamps []time.Time
+	deadline := time.After(400 * time.Millisecond)
+loop:
+	for {
+		select {
+		case <-outCh:
+			timestamps = append(timestamps, time.Now())
+		case <-deadline:
+			break loop
+		}
+	}
+
+	if len(timestamps) < 2 {
+		t.Fatalf("expected multiple emits, got %d", len(timestamps))
+	}
+
+	const tolerance = 10 * time.Millisecond
+	for i := 1; i < len(timestamps); i++ {
+		gap := timestamps[i].Sub(timestamps[i-1])
+		if gap < minEmitInterval-tolerance {
+			t.Errorf("emit %d arrived only %v after previous, want >= %v", i, gap, minEmitInterval)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package solutions
+
+// intAccumulator is a minimal Conflater[T] used across this package's tests:
+// conflating sums deltas, mirroring the demo's Sale.ConflateWith.
+type intAccumulator struct {
+	sum int
+}
+
+func (a intAccumulator) ConflateWith(b intAccumulator) intAccumulator {
+	return intAccumulator{sum: a.sum + b.sum}
+}
+
+func (a intAccumulator) ZeroValue() intAccumulator {
+	return intAccumulator{}
+}